@@ -2,37 +2,148 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
-	"syscall"
 	"time"
 
-	"github.com/matty271828/auth-service/internal/server"
+	"github.com/matty271828/auth-service/internal/api"
+	"github.com/matty271828/auth-service/internal/config"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
 )
 
+const shutdownTimeout = 5 * time.Second
+
 func main() {
-	// Create and start the server
-	srv := server.NewServer(8080)
+	app := &cli.App{
+		Name:  "auth-service",
+		Usage: "runs the auth-service API",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "listen",
+				Usage:   "address for the API server to listen on",
+				EnvVars: []string{"AUTH_LISTEN"},
+			},
+			&cli.StringFlag{
+				Name:  "httpconfig",
+				Usage: "path to a YAML config file",
+			},
+			&cli.StringFlag{
+				Name:    "jwtkey",
+				Usage:   "key material used to sign JWTs",
+				EnvVars: []string{"AUTH_JWT_KEY"},
+			},
+			&cli.StringFlag{
+				Name:  "loglevel",
+				Usage: "initial log level (DEBUG, INFO, WARN, ERROR)",
+			},
+			&cli.StringFlag{
+				Name:  "tlscert",
+				Usage: "path to a TLS certificate",
+			},
+			&cli.StringFlag{
+				Name:  "tlskey",
+				Usage: "path to a TLS private key",
+			},
+			&cli.StringFlag{
+				Name:    "admin-username",
+				Usage:   "username accepted by the built-in signin user store",
+				Value:   "admin",
+				EnvVars: []string{"AUTH_ADMIN_USERNAME"},
+			},
+			&cli.StringFlag{
+				Name:    "admin-password",
+				Usage:   "password accepted by the built-in signin user store",
+				Value:   "admin",
+				EnvVars: []string{"AUTH_ADMIN_PASSWORD"},
+			},
+		},
+		Action: run,
+		Commands: []*cli.Command{
+			versionCommand,
+		},
+	}
 
-	// Start server in a goroutine
-	go func() {
-		if err := srv.Start(); err != nil {
-			log.Printf("Server error: %v\n", err)
-		}
-	}()
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var versionCommand = &cli.Command{
+	Name:  "version",
+	Usage: "print build information and exit",
+	Action: func(c *cli.Context) error {
+		fmt.Printf("version: %s\ncommit: %s\ndate: %s\n", Version, Commit, Date)
+		return nil
+	},
+}
+
+func run(c *cli.Context) error {
+	cfg, err := config.Load(c.String("httpconfig"))
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if listen := c.String("listen"); listen != "" {
+		cfg.Listen = listen
+	}
+	if jwtKey := c.String("jwtkey"); jwtKey != "" {
+		cfg.JWTKey = jwtKey
+	}
+	if loglevel := c.String("loglevel"); loglevel != "" {
+		cfg.LogLevel = loglevel
+	}
+	if tlsCert := c.String("tlscert"); tlsCert != "" {
+		cfg.TLSCert = tlsCert
+	}
+	if tlsKey := c.String("tlskey"); tlsKey != "" {
+		cfg.TLSKey = tlsKey
+	}
+	cfg.Defaults()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	if err := api.SetLogLevel(cfg.LogLevel); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", cfg.LogLevel, err)
+	}
+	if cfg.JWTKey == "" {
+		return errors.New("jwtkey is required: set --jwtkey or the jwtkey config field")
+	}
 
-	// Create shutdown context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	ctx, stop := signal.NotifyContext(context.Background(), shutdownSignals...)
+	defer stop()
 
-	// Attempt graceful shutdown
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Printf("Server shutdown error: %v\n", err)
+	apiServer := api.NewAPIServer(cfg)
+	apiServer.SetUserStore(api.StaticUserStore{
+		c.String("admin-username"): c.String("admin-password"),
+	})
+	introspectionServer := apiServer.Introspection()
+
+	if err := apiServer.Listen(); err != nil {
+		return fmt.Errorf("binding API listener: %w", err)
 	}
+
+	srvs, ctx := errgroup.WithContext(ctx)
+
+	srvs.Go(apiServer.Start)
+	srvs.Go(introspectionServer.Start)
+
+	srvs.Go(func() error {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		var shutdownErr error
+		if err := apiServer.Shutdown(shutdownCtx); err != nil {
+			shutdownErr = err
+		}
+		if err := introspectionServer.Shutdown(shutdownCtx); err != nil {
+			shutdownErr = err
+		}
+		return shutdownErr
+	})
+
+	return srvs.Wait()
 }
@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// shutdownSignals are the signals that trigger a graceful shutdown.
+var shutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
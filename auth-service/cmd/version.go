@@ -0,0 +1,8 @@
+package main
+
+// Version, Commit, and Date are injected at build time via -ldflags.
+var (
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
+)
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	authCookieName = "auth_token"
+	tokenTTL       = 15 * time.Minute
+	refreshWindow  = 5 * time.Minute
+)
+
+// UserStore verifies user credentials for /signin. Callers provide their own
+// backing implementation (database, LDAP, etc.).
+type UserStore interface {
+	Authenticate(ctx context.Context, username, password string) (userID string, err error)
+}
+
+// SetUserStore wires the credential store consulted by /signin. /signin
+// rejects all requests until a store is set.
+func (s *APIServer) SetUserStore(store UserStore) {
+	s.userStore = store
+}
+
+// StaticUserStore is a UserStore backed by an in-memory username/password
+// map. It exists so the signin/refresh/echo path has something to smoke-test
+// against out of the box; production deployments should supply a UserStore
+// backed by a real credential store instead.
+type StaticUserStore map[string]string
+
+func (s StaticUserStore) Authenticate(ctx context.Context, username, password string) (string, error) {
+	want, ok := s[username]
+	if !ok || want != password {
+		return "", errors.New("invalid credentials")
+	}
+	return username, nil
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+}
+
+func (s *APIServer) signToken(userID string) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+		},
+	})
+	return token.SignedString([]byte(s.cfg.JWTKey))
+}
+
+func (s *APIServer) parseToken(tokenString string) (*claims, error) {
+	c := &claims{}
+	token, err := jwt.ParseWithClaims(tokenString, c, func(t *jwt.Token) (interface{}, error) {
+		return []byte(s.cfg.JWTKey), nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return c, nil
+}
+
+func (s *APIServer) setAuthCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     authCookieName,
+		Value:    token,
+		HttpOnly: true,
+		Secure:   s.cfg.TLSEnabled(),
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+		Expires:  time.Now().Add(tokenTTL),
+	})
+}
+
+type signinRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// handleSignin verifies credentials against the configured UserStore and, on
+// success, issues a signed JWT in an HttpOnly cookie.
+func (s *APIServer) handleSignin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.userStore == nil {
+		http.Error(w, "no user store configured", http.StatusInternalServerError)
+		return
+	}
+
+	var req signinRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := s.userStore.Authenticate(r.Context(), req.Username, req.Password)
+	if err != nil {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := s.signToken(userID)
+	if err != nil {
+		http.Error(w, "could not issue token", http.StatusInternalServerError)
+		return
+	}
+
+	s.setAuthCookie(w, token)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleRefresh rotates the caller's token if it is within its refresh
+// window, extending the session without requiring a new sign-in.
+func (s *APIServer) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cookie, err := r.Cookie(authCookieName)
+	if err != nil {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	c, err := s.parseToken(cookie.Value)
+	if err != nil {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	if time.Until(c.ExpiresAt.Time) > refreshWindow {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	token, err := s.signToken(c.Subject)
+	if err != nil {
+		http.Error(w, "could not issue token", http.StatusInternalServerError)
+		return
+	}
+
+	s.setAuthCookie(w, token)
+	w.WriteHeader(http.StatusOK)
+}
+
+type claimsContextKey int
+
+const claimsKey claimsContextKey = 0
+
+// RequireJWT wraps protected routes, rejecting requests without a valid auth
+// cookie and attaching the decoded claims to the request context.
+func (s *APIServer) RequireJWT(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(authCookieName)
+		if err != nil {
+			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			return
+		}
+
+		c, err := s.parseToken(cookie.Value)
+		if err != nil {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), claimsKey, c)))
+	})
+}
+
+// handleEcho is a diagnostic endpoint that returns the caller's decoded
+// claims plus request body, so the auth path is easy to smoke-test
+// end-to-end.
+func (s *APIServer) handleEcho(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	c, _ := r.Context().Value(claimsKey).(*claims)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "could not read request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Claims *claims `json:"claims"`
+		Body   string  `json:"body,omitempty"`
+	}{
+		Claims: c,
+		Body:   string(body),
+	})
+}
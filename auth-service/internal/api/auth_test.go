@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/matty271828/auth-service/internal/config"
+)
+
+func newTestServer(t *testing.T) *APIServer {
+	t.Helper()
+	cfg := &config.Config{JWTKey: "test-secret"}
+	cfg.Defaults()
+	return NewAPIServer(cfg)
+}
+
+func TestSignTokenParseTokenRoundTrip(t *testing.T) {
+	s := newTestServer(t)
+
+	token, err := s.signToken("user-1")
+	if err != nil {
+		t.Fatalf("signToken() error = %v", err)
+	}
+
+	c, err := s.parseToken(token)
+	if err != nil {
+		t.Fatalf("parseToken() error = %v", err)
+	}
+	if c.Subject != "user-1" {
+		t.Fatalf("Subject = %q, want user-1", c.Subject)
+	}
+}
+
+func TestParseTokenRejectsWrongKey(t *testing.T) {
+	s := newTestServer(t)
+	token, err := s.signToken("user-1")
+	if err != nil {
+		t.Fatalf("signToken() error = %v", err)
+	}
+
+	other := newTestServer(t)
+	other.cfg.JWTKey = "different-secret"
+
+	if _, err := other.parseToken(token); err == nil {
+		t.Fatal("parseToken() with the wrong key should fail")
+	}
+}
+
+func TestHandleRefresh(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  time.Duration
+		wantRotate bool
+	}{
+		{
+			name:       "outside refresh window leaves token untouched",
+			expiresIn:  tokenTTL,
+			wantRotate: false,
+		},
+		{
+			name:       "inside refresh window rotates token",
+			expiresIn:  refreshWindow - time.Second,
+			wantRotate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+
+			now := time.Now()
+			c := claims{RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(tt.expiresIn)),
+			}}
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(s.cfg.JWTKey))
+			if err != nil {
+				t.Fatalf("signing fixture token: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
+			req.AddCookie(&http.Cookie{Name: authCookieName, Value: signed})
+			w := httptest.NewRecorder()
+
+			s.handleRefresh(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+
+			rotated := false
+			for _, ck := range w.Result().Cookies() {
+				if ck.Name == authCookieName && ck.Value != signed {
+					rotated = true
+				}
+			}
+			if rotated != tt.wantRotate {
+				t.Fatalf("rotated = %v, want %v", rotated, tt.wantRotate)
+			}
+		})
+	}
+}
+
+func TestHandleRefreshRejectsMissingCookie(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
+	w := httptest.NewRecorder()
+
+	s.handleRefresh(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestHandleEchoEchoesNonJSONBody(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("not json"))
+	req = req.WithContext(context.WithValue(req.Context(), claimsKey, &claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+	}))
+	w := httptest.NewRecorder()
+
+	s.handleEcho(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if !strings.Contains(w.Body.String(), "not json") {
+		t.Fatalf("body = %q, want it to contain the echoed request body", w.Body.String())
+	}
+}
+
+func TestHandleEchoRejectsNonPost(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/echo", nil)
+	w := httptest.NewRecorder()
+
+	s.handleEcho(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestParseTokenRejectsAlgNone(t *testing.T) {
+	s := newTestServer(t)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+	})
+	unsigned, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing fixture token: %v", err)
+	}
+
+	if _, err := s.parseToken(unsigned); err == nil {
+		t.Fatal("parseToken() should reject a token signed with \"none\"")
+	}
+}
+
+func TestStaticUserStoreAuthenticate(t *testing.T) {
+	store := StaticUserStore{"admin": "hunter2"}
+
+	if _, err := store.Authenticate(nil, "admin", "wrong"); err == nil {
+		t.Fatal("Authenticate() with the wrong password should fail")
+	}
+	if _, err := store.Authenticate(nil, "nobody", "hunter2"); err == nil {
+		t.Fatal("Authenticate() with an unknown user should fail")
+	}
+
+	userID, err := store.Authenticate(nil, "admin", "hunter2")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if userID != "admin" {
+		t.Fatalf("userID = %q, want admin", userID)
+	}
+}
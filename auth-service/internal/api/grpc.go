@@ -0,0 +1,10 @@
+package api
+
+import "google.golang.org/grpc"
+
+// RegisterGRPC lets callers attach gRPC services to the server before Start
+// is called. The registered server is multiplexed onto the same listener as
+// the HTTP API.
+func (s *APIServer) RegisterGRPC(register func(*grpc.Server)) {
+	register(s.grpcServer)
+}
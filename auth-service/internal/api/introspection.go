@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/pprof"
+	"sync/atomic"
+
+	"github.com/matty271828/auth-service/internal/config"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// IntrospectionServer exposes Prometheus metrics, health and readiness
+// probes, and pprof handlers on a listener separate from the main API, so
+// scrapers and probes keep working independently of API traffic.
+type IntrospectionServer struct {
+	httpServer *http.Server
+	ready      atomic.Bool
+}
+
+func NewIntrospectionServer(cfg *config.Config) *IntrospectionServer {
+	s := &IntrospectionServer{
+		httpServer: &http.Server{
+			Addr: cfg.IntrospectionListen,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/loglevel", handleLogLevelStatus)
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	s.httpServer.Handler = mux
+
+	return s
+}
+
+func (s *IntrospectionServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *IntrospectionServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !s.Ready() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleLogLevelStatus reports the active log level, so operators can check
+// whether a level change they pushed via the API's /loglevel took effect.
+func handleLogLevelStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Level string `json:"level"`
+	}{Level: logLevel.Level().String()})
+}
+
+// Ready reports whether the API server has finished registering its routes.
+func (s *IntrospectionServer) Ready() bool {
+	return s.ready.Load()
+}
+
+// SetReady flips the readiness flag reported by /readyz.
+func (s *IntrospectionServer) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// Start blocks until the server exits. A clean shutdown triggered via
+// Shutdown is not treated as an error.
+func (s *IntrospectionServer) Start() error {
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown gracefully drains in-flight requests before returning.
+func (s *IntrospectionServer) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// handleLogLevel swaps the active log level without restarting the process.
+func handleLogLevel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req logLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(req.Level)); err != nil {
+		http.Error(w, "unknown log level", http.StatusBadRequest)
+		return
+	}
+
+	logLevel.Set(level)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleLogLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantLevel  slog.Level
+	}{
+		{
+			name:       "valid level",
+			method:     http.MethodPost,
+			body:       `{"level":"DEBUG"}`,
+			wantStatus: http.StatusOK,
+			wantLevel:  slog.LevelDebug,
+		},
+		{
+			name:       "unknown level rejected",
+			method:     http.MethodPost,
+			body:       `{"level":"VERBOSE"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "malformed body rejected",
+			method:     http.MethodPost,
+			body:       `not json`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method rejected",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logLevel.Set(slog.LevelInfo)
+
+			req := httptest.NewRequest(tt.method, "/loglevel", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handleLogLevel(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && logLevel.Level() != tt.wantLevel {
+				t.Fatalf("level = %v, want %v", logLevel.Level(), tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	defer logLevel.Set(slog.LevelInfo)
+
+	if err := SetLogLevel("WARN"); err != nil {
+		t.Fatalf("SetLogLevel() error = %v", err)
+	}
+	if logLevel.Level() != slog.LevelWarn {
+		t.Fatalf("level = %v, want WARN", logLevel.Level())
+	}
+
+	if err := SetLogLevel("NOPE"); err == nil {
+		t.Fatal("SetLogLevel() with an invalid level should error")
+	}
+}
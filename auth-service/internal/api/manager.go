@@ -1,22 +1,164 @@
 package api
 
-import "net/http"
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/matty271828/auth-service/internal/config"
+	"github.com/soheilhy/cmux"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
 
 const (
 	BaseURL = "worker"
 )
 
+// APIServer serves the worker HTTP API and, via RegisterGRPC, any gRPC
+// services, multiplexed onto a single listener with cmux.
 type APIServer struct {
+	httpServer    *http.Server
+	grpcServer    *grpc.Server
+	introspection *IntrospectionServer
+	cfg           *config.Config
+	userStore     UserStore
+
+	listener net.Listener
 }
 
-func NewAPIServer() *APIServer {
-	return &APIServer{}
+func NewAPIServer(cfg *config.Config) *APIServer {
+	return &APIServer{
+		httpServer: &http.Server{
+			ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+			ReadTimeout:       cfg.HTTP.ReadTimeout,
+			WriteTimeout:      cfg.HTTP.WriteTimeout,
+			IdleTimeout:       cfg.HTTP.IdleTimeout,
+		},
+		grpcServer:    grpc.NewServer(),
+		introspection: NewIntrospectionServer(cfg),
+		cfg:           cfg,
+	}
 }
 
-func (s *APIServer) Start() {
-	http.ListenAndServe(":9000", nil)
+// Introspection returns the server's metrics/health/readiness listener so
+// callers can add it to their own lifecycle management alongside Start and
+// Shutdown.
+func (s *APIServer) Introspection() *IntrospectionServer {
+	return s.introspection
+}
+
+// Listen registers the API routes and binds the listener synchronously.
+// Callers should call Listen before handing Start off to a goroutine (e.g.
+// via errgroup.Go), so a bind failure surfaces immediately and Shutdown can
+// never race a nil listener. Start calls Listen itself if it hasn't already
+// run.
+func (s *APIServer) Listen() error {
+	if s.listener != nil {
+		return nil
+	}
+
+	s.registerEndpoints()
+
+	lis, err := net.Listen("tcp", s.cfg.Listen)
+	if err != nil {
+		return err
+	}
+	s.listener = lis
+	return nil
+}
+
+// Start serves the bound listener with cmux and blocks until the server
+// exits. Connections that open with an HTTP/2 request carrying content-type
+// application/grpc go to the gRPC server; every other connection goes to the
+// HTTP server. gRPC-web requests are not translated: they reach the HTTP
+// routes like any other request. Matching reads the raw connection bytes, so
+// with TLS enabled every connection goes to the HTTP server.
+func (s *APIServer) Start() error {
+	if err := s.Listen(); err != nil {
+		return err
+	}
+
+	m := cmux.New(s.listener)
+	grpcL := m.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"))
+	httpL := m.Match(cmux.Any())
+
+	g := &errgroup.Group{}
+
+	g.Go(func() error {
+		if err := s.grpcServer.Serve(grpcL); err != nil && !isShutdownErr(err) && !errors.Is(err, grpc.ErrServerStopped) {
+			return err
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		var err error
+		if s.cfg.TLSEnabled() {
+			err = s.httpServer.ServeTLS(httpL, s.cfg.TLSCert, s.cfg.TLSKey)
+		} else {
+			err = s.httpServer.Serve(httpL)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) && !isShutdownErr(err) {
+			return err
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		if err := m.Serve(); err != nil && !isShutdownErr(err) {
+			return err
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// Shutdown stops the gRPC and HTTP servers concurrently before closing the
+// shared listener.
+func (s *APIServer) Shutdown(ctx context.Context) error {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		s.grpcServer.GracefulStop()
+	}()
+
+	var httpErr error
+	go func() {
+		defer wg.Done()
+		httpErr = s.httpServer.Shutdown(ctx)
+	}()
+
+	wg.Wait()
+
+	if s.listener != nil {
+		if err := s.listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) && httpErr == nil {
+			return err
+		}
+	}
+	return httpErr
+}
+
+// isShutdownErr reports whether err is the expected result of closing the
+// shared listener during Shutdown, rather than a genuine serve failure.
+func isShutdownErr(err error) bool {
+	return errors.Is(err, cmux.ErrListenerClosed) || errors.Is(err, cmux.ErrServerClosed) || errors.Is(err, net.ErrClosed)
 }
 
 func (s *APIServer) registerEndpoints() {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/loglevel", handleLogLevel)
+	mux.HandleFunc("/signin", s.handleSignin)
+	mux.HandleFunc("/refresh", s.handleRefresh)
+	mux.Handle("/echo", s.RequireJWT(http.HandlerFunc(s.handleEcho)))
+
+	s.httpServer.Handler = loggingMiddleware(mux)
 
+	s.introspection.SetReady(true)
 }
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matty271828/auth-service/internal/config"
+)
+
+func TestAPIServerStartShutdown(t *testing.T) {
+	cfg := &config.Config{JWTKey: "secret", Listen: "127.0.0.1:0"}
+	cfg.Defaults()
+
+	s := NewAPIServer(cfg)
+	s.SetUserStore(StaticUserStore{"admin": "admin"})
+
+	if err := s.Listen(); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Start() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start() did not return after Shutdown")
+	}
+}
+
+func TestAPIServerListenIdempotent(t *testing.T) {
+	cfg := &config.Config{JWTKey: "secret", Listen: "127.0.0.1:0"}
+	cfg.Defaults()
+
+	s := NewAPIServer(cfg)
+
+	if err := s.Listen(); err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	lis := s.listener
+
+	if err := s.Listen(); err != nil {
+		t.Fatalf("second Listen() error = %v", err)
+	}
+	if s.listener != lis {
+		t.Fatal("second Listen() rebound the listener")
+	}
+
+	if err := s.listener.Close(); err != nil {
+		t.Fatalf("closing listener: %v", err)
+	}
+}
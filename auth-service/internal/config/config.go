@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the runtime configuration shared by the auth-service servers.
+type Config struct {
+	Listen              string     `yaml:"listen"`
+	IntrospectionListen string     `yaml:"introspection_listen"`
+	HTTP                HTTPConfig `yaml:"httpconfig"`
+	JWTKey              string     `yaml:"jwtkey"`
+	LogLevel            string     `yaml:"loglevel"`
+	TLSCert             string     `yaml:"tlscert"`
+	TLSKey              string     `yaml:"tlskey"`
+}
+
+// HTTPConfig tunes the timeouts applied to the main API server.
+type HTTPConfig struct {
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+}
+
+// Load reads a YAML config file from path. An empty path is not an error;
+// callers get a zero-valued Config, which Defaults then fills in.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		return &Config{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Defaults fills in any zero-valued fields with the package defaults.
+func (c *Config) Defaults() {
+	if c.Listen == "" {
+		c.Listen = ":9000"
+	}
+	if c.IntrospectionListen == "" {
+		c.IntrospectionListen = ":9090"
+	}
+	if c.LogLevel == "" {
+		c.LogLevel = "INFO"
+	}
+	if c.HTTP.ReadHeaderTimeout == 0 {
+		c.HTTP.ReadHeaderTimeout = 5 * time.Second
+	}
+	if c.HTTP.ReadTimeout == 0 {
+		c.HTTP.ReadTimeout = 15 * time.Second
+	}
+	if c.HTTP.WriteTimeout == 0 {
+		c.HTTP.WriteTimeout = 15 * time.Second
+	}
+	if c.HTTP.IdleTimeout == 0 {
+		c.HTTP.IdleTimeout = 60 * time.Second
+	}
+}
+
+// TLSEnabled reports whether both a certificate and key were configured.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCert != "" && c.TLSKey != ""
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		path    string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "empty path returns zero value",
+			path: "",
+			want: Config{},
+		},
+		{
+			name: "parses yaml fields",
+			yaml: "listen: \":9001\"\njwtkey: secret\nloglevel: DEBUG\n",
+			want: Config{Listen: ":9001", JWTKey: "secret", LogLevel: "DEBUG"},
+		},
+		{
+			name:    "missing file errors",
+			path:    "/does/not/exist.yaml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if tt.yaml != "" {
+				path = filepath.Join(t.TempDir(), "config.yaml")
+				if err := os.WriteFile(path, []byte(tt.yaml), 0o600); err != nil {
+					t.Fatalf("writing fixture: %v", err)
+				}
+			}
+
+			got, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != tt.want {
+				t.Fatalf("Load() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	cfg := &Config{}
+	cfg.Defaults()
+
+	if cfg.Listen != ":9000" {
+		t.Errorf("Listen = %q, want :9000", cfg.Listen)
+	}
+	if cfg.LogLevel != "INFO" {
+		t.Errorf("LogLevel = %q, want INFO", cfg.LogLevel)
+	}
+	if cfg.HTTP.ReadHeaderTimeout != 5*time.Second {
+		t.Errorf("ReadHeaderTimeout = %v, want 5s", cfg.HTTP.ReadHeaderTimeout)
+	}
+	if cfg.HTTP.ReadTimeout != 15*time.Second {
+		t.Errorf("ReadTimeout = %v, want 15s", cfg.HTTP.ReadTimeout)
+	}
+	if cfg.HTTP.WriteTimeout != 15*time.Second {
+		t.Errorf("WriteTimeout = %v, want 15s", cfg.HTTP.WriteTimeout)
+	}
+	if cfg.HTTP.IdleTimeout != 60*time.Second {
+		t.Errorf("IdleTimeout = %v, want 60s", cfg.HTTP.IdleTimeout)
+	}
+
+	cfg2 := &Config{Listen: ":1234", LogLevel: "WARN"}
+	cfg2.Defaults()
+	if cfg2.Listen != ":1234" || cfg2.LogLevel != "WARN" {
+		t.Errorf("Defaults() overwrote explicit values: %+v", cfg2)
+	}
+}
+
+func TestTLSEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want bool
+	}{
+		{"neither set", Config{}, false},
+		{"only cert", Config{TLSCert: "cert.pem"}, false},
+		{"only key", Config{TLSKey: "key.pem"}, false},
+		{"both set", Config{TLSCert: "cert.pem", TLSKey: "key.pem"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.TLSEnabled(); got != tt.want {
+				t.Errorf("TLSEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}